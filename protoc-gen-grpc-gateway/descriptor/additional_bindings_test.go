@@ -0,0 +1,78 @@
+package descriptor
+
+import (
+	"strings"
+	"testing"
+
+	options "github.com/gengo/grpc-gateway/third_party/googleapis/google/api"
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestNewMethodsAdditionalBindings(t *testing.T) {
+	file := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+
+	req := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Req")}}
+	req.Fields = []*Field{
+		newField(req, "id", descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+	}
+	resp := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Resp")}}
+
+	r := NewRegistry()
+	r.msgs[".example.Req"] = req
+	r.msgs[".example.Resp"] = resp
+
+	svc := &Service{File: file, ServiceDescriptorProto: &descriptorpb.ServiceDescriptorProto{Name: strp("Svc")}}
+	md := &descriptorpb.MethodDescriptorProto{
+		Name:       strp("Get"),
+		InputType:  strp(".example.Req"),
+		OutputType: strp(".example.Resp"),
+	}
+
+	t.Run("primary plus one additional binding", func(t *testing.T) {
+		opts := &options.HttpRule{
+			Get: "/v1/messages/{id}",
+			AdditionalBindings: []*options.HttpRule{
+				{Post: "/v1/messages"},
+			},
+		}
+
+		meths, err := r.newMethods(svc, md, opts)
+		if err != nil {
+			t.Fatalf("newMethods() failed with %v; want success", err)
+		}
+		if len(meths) != 2 {
+			t.Fatalf("newMethods() returned %d methods; want 2", len(meths))
+		}
+		if meths[0].HTTPMethod != "GET" || meths[1].HTTPMethod != "POST" {
+			t.Errorf("newMethods() HTTP methods = [%s, %s]; want [GET, POST]", meths[0].HTTPMethod, meths[1].HTTPMethod)
+		}
+		for i, m := range meths {
+			if m.RequestType != req || m.ResponseType != resp {
+				t.Errorf("newMethods()[%d] RequestType/ResponseType = %v/%v; want %v/%v", i, m.RequestType, m.ResponseType, req, resp)
+			}
+			if m.Service != svc {
+				t.Errorf("newMethods()[%d].Service = %v; want %v", i, m.Service, svc)
+			}
+		}
+	})
+
+	t.Run("nested additional_bindings rejected", func(t *testing.T) {
+		opts := &options.HttpRule{
+			Get: "/v1/messages/{id}",
+			AdditionalBindings: []*options.HttpRule{
+				{
+					Post:               "/v1/messages",
+					AdditionalBindings: []*options.HttpRule{{Put: "/v1/messages/{id}"}},
+				},
+			},
+		}
+
+		_, err := r.newMethods(svc, md, opts)
+		if err == nil {
+			t.Fatal("newMethods() succeeded; want an error")
+		}
+		if !strings.Contains(err.Error(), "additional_bindings in an additional_binding") {
+			t.Errorf("newMethods() error = %v; want it to mention nested additional_bindings", err)
+		}
+	})
+}
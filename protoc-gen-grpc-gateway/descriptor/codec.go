@@ -0,0 +1,104 @@
+package descriptor
+
+import "mime"
+
+// Codec describes how request and response bodies of a given content
+// type are marshaled on the wire. A Codec carries no behavior itself;
+// it carries the go expressions and imports the generator needs to
+// construct a concrete decoder/encoder for it.
+type Codec struct {
+	// Name identifies the codec, e.g. "jsonpb", "protobuf", "msgpack".
+	Name string
+
+	// ContentTypes lists the HTTP content types handled by this codec,
+	// e.g. "application/json". The generated runtime matches a request's
+	// Content-Type, and a response's Accept header, against this list.
+	ContentTypes []string
+
+	// DecoderFactoryExpr is a go expression evaluating to a function
+	// that takes an io.Reader and returns a decoder with a
+	// Decode(proto.Message) error method.
+	DecoderFactoryExpr string
+	// EncoderFactoryExpr is the encoder equivalent of DecoderFactoryExpr:
+	// a go expression evaluating to a function that takes an io.Writer
+	// and returns an encoder with an Encode(proto.Message) error method.
+	EncoderFactoryExpr string
+	// Imports is the list of import paths required by
+	// DecoderFactoryExpr and EncoderFactoryExpr.
+	Imports []GoPackage
+}
+
+var (
+	// CodecJSONPb decodes and encodes bodies with jsonpb, which -
+	// unlike encoding/json - honors the proto3 JSON mapping: enum names,
+	// oneof, well-known types such as google.protobuf.Timestamp, and
+	// int64 represented as a JSON string.
+	CodecJSONPb = &Codec{
+		Name:               "jsonpb",
+		ContentTypes:       []string{"application/json"},
+		DecoderFactoryExpr: "jsonpb.NewDecoder",
+		EncoderFactoryExpr: "jsonpb.NewEncoder",
+		Imports: []GoPackage{
+			{Path: "github.com/golang/protobuf/jsonpb", Name: "jsonpb"},
+		},
+	}
+
+	// CodecProto decodes and encodes bodies as raw binary-encoded
+	// protocol buffers.
+	CodecProto = &Codec{
+		Name:               "protobuf",
+		ContentTypes:       []string{"application/x-protobuf"},
+		DecoderFactoryExpr: "proto.NewDecoder",
+		EncoderFactoryExpr: "proto.NewEncoder",
+		Imports: []GoPackage{
+			{Path: "github.com/golang/protobuf/proto", Name: "proto"},
+		},
+	}
+
+	// CodecMsgpack decodes and encodes bodies with msgpack. Unlike
+	// CodecJSONPb and CodecProto it is not registered by default; opt in
+	// with Registry.RegisterCodec.
+	CodecMsgpack = &Codec{
+		Name:               "msgpack",
+		ContentTypes:       []string{"application/x-msgpack"},
+		DecoderFactoryExpr: "msgpack.NewDecoder",
+		EncoderFactoryExpr: "msgpack.NewEncoder",
+		Imports: []GoPackage{
+			{Path: "github.com/ugorji/go/codec", Name: "codec", Alias: "msgpack"},
+		},
+	}
+)
+
+// RegisterCodec makes codec available for every content type it lists,
+// overriding any codec already registered for those content types.
+func (r *Registry) RegisterCodec(codec *Codec) {
+	for _, ct := range codec.ContentTypes {
+		r.codecsByContentType[ct] = codec
+	}
+}
+
+// SetDefaultCodec sets the codec used when a request carries no
+// Content-Type, or a response's Accept header matches nothing registered.
+func (r *Registry) SetDefaultCodec(codec *Codec) {
+	r.defaultCodec = codec
+}
+
+// DefaultCodec returns the codec used when content negotiation doesn't
+// otherwise select one.
+func (r *Registry) DefaultCodec() *Codec {
+	return r.defaultCodec
+}
+
+// CodecForContentType returns the codec registered for contentType, or
+// the default codec if none is registered for it. contentType may carry
+// parameters, e.g. "application/json; charset=utf-8"; they are stripped
+// before the lookup.
+func (r *Registry) CodecForContentType(contentType string) *Codec {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+	if codec, ok := r.codecsByContentType[contentType]; ok {
+		return codec
+	}
+	return r.defaultCodec
+}
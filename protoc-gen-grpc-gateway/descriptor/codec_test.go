@@ -0,0 +1,33 @@
+package descriptor
+
+import "testing"
+
+func TestCodecForContentType(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterCodec(CodecMsgpack)
+
+	cases := []struct {
+		contentType string
+		want        *Codec
+	}{
+		{"application/json", CodecJSONPb},
+		{"application/json; charset=utf-8", CodecJSONPb},
+		{"application/x-protobuf", CodecProto},
+		{"application/x-msgpack", CodecMsgpack},
+		{"application/octet-stream", CodecJSONPb}, // falls back to the default
+	}
+	for _, c := range cases {
+		if got := r.CodecForContentType(c.contentType); got != c.want {
+			t.Errorf("CodecForContentType(%q) = %v; want %v", c.contentType, got, c.want)
+		}
+	}
+}
+
+func TestCodecForContentTypeHonorsDefault(t *testing.T) {
+	r := NewRegistry()
+	r.SetDefaultCodec(CodecProto)
+
+	if got := r.CodecForContentType("text/plain"); got != CodecProto {
+		t.Errorf("CodecForContentType(%q) = %v; want %v", "text/plain", got, CodecProto)
+	}
+}
@@ -0,0 +1,97 @@
+package descriptor
+
+import (
+	descriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// QueryParameter is a parameter bound from the HTTP query string, e.g.
+// "?foo=1&bar.baz=2". Unlike a path Parameter, a query parameter may be
+// repeated ("?tag=a&tag=b") when its Target field is itself repeated.
+type QueryParameter struct {
+	// FieldPath is a path to a proto field which this parameter is mapped to.
+	FieldPath
+	// Target is the proto field which this parameter is mapped to.
+	Target *Field
+}
+
+// newQueryParams returns the list of QueryParameters for meth, derived by
+// walking meth.RequestType recursively and collecting every scalar leaf
+// field that isn't already bound by meth.PathParams or meth.Body.
+// Nested message fields are addressed with dotted names ("foo.bar").
+func (r *Registry) newQueryParams(meth *Method) ([]QueryParameter, error) {
+	covered := make(map[string]bool)
+	for _, p := range meth.PathParams {
+		covered[p.FieldPath.String()] = true
+	}
+	if meth.Body != nil {
+		if meth.Body.FieldPath == nil {
+			// "body: *" consumes the whole request; there's nothing left
+			// to bind from the query string.
+			return nil, nil
+		}
+		covered[meth.Body.FieldPath.String()] = true
+	}
+
+	// seen guards against unbounded recursion through a self- or
+	// mutually-recursive message (e.g. "message Comment { Comment parent
+	// = 1; }", a normal and valid proto construct). It tracks the
+	// messages on the current recursion path, keyed by FQMN, not every
+	// message visited overall, so the same message type can still appear
+	// in separate branches (e.g. both "author" and "assignee" being a
+	// "User").
+	seen := map[string]bool{meth.RequestType.FQMN(): true}
+
+	var params []QueryParameter
+	if err := r.collectQueryParams(meth.RequestType, nil, covered, seen, &params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// collectQueryParams appends a QueryParameter for every scalar leaf field
+// reachable from msg, prefixing each with the field path accumulated so
+// far in prefix. Fields (or any of their ancestors) present in covered
+// are skipped since they're already bound elsewhere. seen holds the
+// FQMNs of the messages on the current recursion path so that a
+// self-referential message stops recursion instead of overflowing the
+// stack.
+func (r *Registry) collectQueryParams(msg *Message, prefix []FieldPathComponent, covered map[string]bool, seen map[string]bool, params *[]QueryParameter) error {
+	for _, f := range msg.Fields {
+		fp := append(append([]FieldPathComponent{}, prefix...), FieldPathComponent{Name: f.GetName(), Target: f})
+		if covered[FieldPath(fp).String()] {
+			continue
+		}
+
+		switch f.GetType() {
+		case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+			if f.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+				// Binding into a repeated nested message via the query
+				// string isn't supported; leave it for the request body.
+				continue
+			}
+			nested, err := r.LookupMsg(msg.FQMN(), f.GetTypeName())
+			if err != nil {
+				return err
+			}
+			fqmn := nested.FQMN()
+			if seen[fqmn] {
+				// Revisiting a message already on this recursion path:
+				// binding it further would never terminate, so stop here
+				// instead of descending again.
+				continue
+			}
+			seen[fqmn] = true
+			err = r.collectQueryParams(nested, fp, covered, seen, params)
+			delete(seen, fqmn)
+			if err != nil {
+				return err
+			}
+		default:
+			*params = append(*params, QueryParameter{
+				FieldPath: FieldPath(fp),
+				Target:    f,
+			})
+		}
+	}
+	return nil
+}
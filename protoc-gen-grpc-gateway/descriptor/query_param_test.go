@@ -0,0 +1,90 @@
+package descriptor
+
+import (
+	"sort"
+	"testing"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func strp(s string) *string { return &s }
+
+func newField(msg *Message, name string, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, typeName string) *Field {
+	fd := &descriptorpb.FieldDescriptorProto{
+		Name:  strp(name),
+		Type:  typ.Enum(),
+		Label: label.Enum(),
+	}
+	if typeName != "" {
+		fd.TypeName = strp(typeName)
+	}
+	return &Field{Message: msg, FieldDescriptorProto: fd}
+}
+
+func TestNewQueryParams(t *testing.T) {
+	file := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+
+	nested := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Nested")}}
+	nested.Fields = []*Field{
+		newField(nested, "value", descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+	}
+
+	req := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Req")}}
+	req.Fields = []*Field{
+		newField(req, "id", descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+		newField(req, "nested", descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".example.Nested"),
+		newField(req, "tags", descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""),
+	}
+
+	r := NewRegistry()
+	r.msgs[".example.Nested"] = nested
+	r.msgs[".example.Req"] = req
+
+	meth := &Method{
+		RequestType: req,
+		PathParams: []Parameter{
+			{FieldPath: FieldPath{{Name: "id", Target: req.Fields[0]}}},
+		},
+	}
+
+	params, err := r.newQueryParams(meth)
+	if err != nil {
+		t.Fatalf("newQueryParams() failed with %v; want success", err)
+	}
+
+	var got []string
+	for _, p := range params {
+		got = append(got, p.FieldPath.String())
+	}
+	sort.Strings(got)
+	want := []string{"nested.value", "tags"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("newQueryParams() = %v; want %v", got, want)
+	}
+}
+
+func TestNewQueryParamsSelfReferential(t *testing.T) {
+	file := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+
+	comment := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Comment")}}
+	comment.Fields = []*Field{
+		newField(comment, "parent", descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".example.Comment"),
+		newField(comment, "text", descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+	}
+
+	r := NewRegistry()
+	r.msgs[".example.Comment"] = comment
+
+	meth := &Method{RequestType: comment}
+
+	// A self-referential message must not send newQueryParams into
+	// unbounded recursion.
+	params, err := r.newQueryParams(meth)
+	if err != nil {
+		t.Fatalf("newQueryParams() failed with %v; want success", err)
+	}
+
+	if len(params) != 1 || params[0].FieldPath.String() != "text" {
+		t.Errorf("newQueryParams() = %v; want a single \"text\" parameter", params)
+	}
+}
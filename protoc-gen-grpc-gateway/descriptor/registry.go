@@ -0,0 +1,73 @@
+package descriptor
+
+import (
+	descriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// Load populates r from req: every file in req.ProtoFile is registered,
+// along with every message and enum it declares (including nested
+// types), so that loadServices can resolve names of message types and
+// their fields by the time it runs. It then calls loadServices for every
+// file named in req.FileToGenerate.
+func (r *Registry) Load(req *plugin.CodeGeneratorRequest) error {
+	for _, fd := range req.GetProtoFile() {
+		r.loadFile(fd)
+	}
+	for _, target := range req.GetFileToGenerate() {
+		if err := r.loadServices(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadFile registers fd, and every message and enum it declares
+// (including ones nested inside another message), with r.
+func (r *Registry) loadFile(fd *descriptor.FileDescriptorProto) *File {
+	file := &File{FileDescriptorProto: fd}
+	r.files[fd.GetName()] = file
+
+	for _, md := range fd.GetMessageType() {
+		r.loadMessage(file, nil, md)
+	}
+	for _, ed := range fd.GetEnumType() {
+		r.loadEnum(file, nil, ed)
+	}
+	return file
+}
+
+// loadMessage registers md, and recursively every type nested inside it,
+// with r. outers is the list of message names md is nested within, outermost first.
+func (r *Registry) loadMessage(file *File, outers []string, md *descriptor.DescriptorProto) *Message {
+	msg := &Message{
+		File:            file,
+		Outers:          outers,
+		DescriptorProto: md,
+	}
+	for _, fd := range md.GetField() {
+		msg.Fields = append(msg.Fields, &Field{Message: msg, FieldDescriptorProto: fd})
+	}
+	r.msgs[msg.FQMN()] = msg
+
+	nestedOuters := append(append([]string{}, outers...), md.GetName())
+	for _, nested := range md.GetNestedType() {
+		r.loadMessage(file, nestedOuters, nested)
+	}
+	for _, ed := range md.GetEnumType() {
+		r.loadEnum(file, nestedOuters, ed)
+	}
+	return msg
+}
+
+// loadEnum registers ed with r. outers is the list of message names ed
+// is nested within, outermost first, or nil for a file-level enum.
+func (r *Registry) loadEnum(file *File, outers []string, ed *descriptor.EnumDescriptorProto) *Enum {
+	enum := &Enum{
+		File:                file,
+		Outers:              outers,
+		EnumDescriptorProto: ed,
+	}
+	r.enums[enum.FQEN()] = enum
+	return enum
+}
@@ -22,7 +22,7 @@ func (r *Registry) loadServices(targetFile string) error {
 	var svcs []*Service
 	for _, sd := range file.GetService() {
 		svc := &Service{
-			File: file,
+			File:                   file,
 			ServiceDescriptorProto: sd,
 		}
 		for _, md := range sd.GetMethod() {
@@ -35,11 +35,11 @@ func (r *Registry) loadServices(targetFile string) error {
 				glog.V(1).Infof("Skip non-target method: %s.%s", svc.GetName(), md.GetName())
 				continue
 			}
-			meth, err := r.newMethod(svc, md, opts)
+			meths, err := r.newMethods(svc, md, opts)
 			if err != nil {
 				return err
 			}
-			svc.Methods = append(svc.Methods, meth)
+			svc.Methods = append(svc.Methods, meths...)
 		}
 		if len(svc.Methods) == 0 {
 			continue
@@ -50,6 +50,31 @@ func (r *Registry) loadServices(targetFile string) error {
 	return nil
 }
 
+// newMethods builds one *Method per HTTP binding declared on md: the
+// primary one described directly by opts, plus one for each entry in
+// opts.AdditionalBindings. All of them share the same
+// ServiceDescriptorProto/RequestType/ResponseType and are registered on
+// svc.Methods so that they route to the same gRPC stub.
+func (r *Registry) newMethods(svc *Service, md *descriptor.MethodDescriptorProto, opts *options.HttpRule) ([]*Method, error) {
+	meth, err := r.newMethod(svc, md, opts)
+	if err != nil {
+		return nil, err
+	}
+	meths := []*Method{meth}
+
+	for _, b := range opts.AdditionalBindings {
+		if len(b.AdditionalBindings) > 0 {
+			return nil, fmt.Errorf("additional_bindings in an additional_binding is not allowed: %s.%s", svc.GetName(), md.GetName())
+		}
+		m, err := r.newMethod(svc, md, b)
+		if err != nil {
+			return nil, err
+		}
+		meths = append(meths, m)
+	}
+	return meths, nil
+}
+
 func (r *Registry) newMethod(svc *Service, md *descriptor.MethodDescriptorProto, opts *options.HttpRule) (*Method, error) {
 	var (
 		httpMethod   string
@@ -117,6 +142,8 @@ func (r *Registry) newMethod(svc *Service, md *descriptor.MethodDescriptorProto,
 		HTTPMethod:            httpMethod,
 		RequestType:           requestType,
 		ResponseType:          responseType,
+		ClientStreaming:       md.GetClientStreaming(),
+		ServerStreaming:       md.GetServerStreaming(),
 	}
 
 	for _, f := range tmpl.Fields {
@@ -127,13 +154,16 @@ func (r *Registry) newMethod(svc *Service, md *descriptor.MethodDescriptorProto,
 		meth.PathParams = append(meth.PathParams, param)
 	}
 
-	// TODO(yugui) Handle query params
-
 	meth.Body, err = r.newBody(meth, opts.Body)
 	if err != nil {
 		return nil, err
 	}
 
+	meth.QueryParams, err = r.newQueryParams(meth)
+	if err != nil {
+		return nil, err
+	}
+
 	return meth, nil
 }
 
@@ -166,32 +196,40 @@ func (r *Registry) newParam(meth *Method, path string) (Parameter, error) {
 		return Parameter{}, fmt.Errorf("invalid field access list for %s", path)
 	}
 	target := fields[l-1].Target
+	var parser ParamParser
 	switch target.GetType() {
 	case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
-		return Parameter{}, fmt.Errorf("aggregate type %s in parameter of %s.%s: %s", target.Type, meth.Service.GetName(), meth.GetName(), path)
+		nested, err := r.LookupMsg(target.Message.FQMN(), target.GetTypeName())
+		if err != nil {
+			return Parameter{}, err
+		}
+		var ok bool
+		parser, ok = r.LookupParamParser(nested.FQMN())
+		if !ok {
+			return Parameter{}, fmt.Errorf("aggregate type %s in parameter of %s.%s: %s", target.Type, meth.Service.GetName(), meth.GetName(), path)
+		}
 	}
 	return Parameter{
-		FieldPath: FieldPath(fields),
-		Method:    meth,
-		Target:    fields[l-1].Target,
+		FieldPath:     FieldPath(fields),
+		Method:        meth,
+		Target:        fields[l-1].Target,
+		ParserExpr:    parser.Expr,
+		ParserImports: parser.Imports,
 	}, nil
 }
 
 func (r *Registry) newBody(meth *Method, path string) (*Body, error) {
 	msg := meth.RequestType
+	codec := r.DefaultCodec()
 	switch path {
 	case "":
 		return nil, nil
 	case "*":
 		return &Body{
-			DecoderFactoryExpr: "json.NewDecoder",
-			DecoderImports: []GoPackage{
-				{
-					Path: "encoding/json",
-					Name: "json",
-				},
-			},
-			FieldPath: nil,
+			Codec:              codec,
+			DecoderFactoryExpr: codec.DecoderFactoryExpr,
+			DecoderImports:     codec.Imports,
+			FieldPath:          nil,
 		}, nil
 	}
 	fields, err := r.resolveFiledPath(msg, path)
@@ -199,14 +237,10 @@ func (r *Registry) newBody(meth *Method, path string) (*Body, error) {
 		return nil, err
 	}
 	return &Body{
-		DecoderFactoryExpr: "json.NewDecoder",
-		DecoderImports: []GoPackage{
-			{
-				Path: "encoding/json",
-				Name: "json",
-			},
-		},
-		FieldPath: FieldPath(fields),
+		Codec:              codec,
+		DecoderFactoryExpr: codec.DecoderFactoryExpr,
+		DecoderImports:     codec.Imports,
+		FieldPath:          FieldPath(fields),
 	}, nil
 }
 
@@ -252,4 +286,4 @@ func (r *Registry) resolveFiledPath(msg *Message, path string) ([]FieldPathCompo
 		result = append(result, FieldPathComponent{Name: c, Target: f})
 	}
 	return result, nil
-}
\ No newline at end of file
+}
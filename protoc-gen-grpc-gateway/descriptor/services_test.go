@@ -0,0 +1,58 @@
+package descriptor
+
+import (
+	"strings"
+	"testing"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestNewParamMessageTyped(t *testing.T) {
+	file := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+	wktFile := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("google.protobuf")}}
+
+	timestamp := &Message{File: wktFile, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Timestamp")}}
+
+	nested := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Nested")}}
+
+	req := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Req")}}
+	req.Fields = []*Field{
+		newField(req, "ts", descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".google.protobuf.Timestamp"),
+		newField(req, "nested", descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".example.Nested"),
+	}
+
+	r := NewRegistry()
+	r.msgs[".google.protobuf.Timestamp"] = timestamp
+	r.msgs[".example.Nested"] = nested
+	r.msgs[".example.Req"] = req
+
+	svc := &Service{File: file, ServiceDescriptorProto: &descriptorpb.ServiceDescriptorProto{Name: strp("Svc")}}
+	meth := &Method{
+		Service:               svc,
+		MethodDescriptorProto: &descriptorpb.MethodDescriptorProto{Name: strp("Get")},
+		RequestType:           req,
+	}
+
+	t.Run("registered parser found", func(t *testing.T) {
+		param, err := r.newParam(meth, "ts")
+		if err != nil {
+			t.Fatalf("newParam() failed with %v; want success", err)
+		}
+		if param.ParserExpr != "runtime.TimestampParam" {
+			t.Errorf("newParam().ParserExpr = %q; want %q", param.ParserExpr, "runtime.TimestampParam")
+		}
+		if len(param.ParserImports) != 1 || param.ParserImports[0] != runtimePackage {
+			t.Errorf("newParam().ParserImports = %v; want [%v]", param.ParserImports, runtimePackage)
+		}
+	})
+
+	t.Run("aggregate type with no registered parser", func(t *testing.T) {
+		_, err := r.newParam(meth, "nested")
+		if err == nil {
+			t.Fatal("newParam() succeeded; want an error")
+		}
+		if !strings.Contains(err.Error(), "aggregate type") {
+			t.Errorf("newParam() error = %v; want it to mention \"aggregate type\"", err)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package descriptor
+
+import (
+	"strings"
+	"testing"
+
+	options "github.com/gengo/grpc-gateway/third_party/googleapis/google/api"
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestNewMethodStreamingFlags(t *testing.T) {
+	file := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+
+	req := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Req")}}
+	resp := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Resp")}}
+
+	r := NewRegistry()
+	r.msgs[".example.Req"] = req
+	r.msgs[".example.Resp"] = resp
+
+	svc := &Service{File: file, ServiceDescriptorProto: &descriptorpb.ServiceDescriptorProto{Name: strp("Svc")}}
+
+	cases := []struct {
+		name            string
+		clientStreaming bool
+		serverStreaming bool
+	}{
+		{"unary", false, false},
+		{"server streaming", false, true},
+		{"bidi streaming", true, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			md := &descriptorpb.MethodDescriptorProto{
+				Name:            strp("Call"),
+				InputType:       strp(".example.Req"),
+				OutputType:      strp(".example.Resp"),
+				ClientStreaming: &c.clientStreaming,
+				ServerStreaming: &c.serverStreaming,
+			}
+			opts := &options.HttpRule{Get: "/v1/call"}
+
+			meth, err := r.newMethod(svc, md, opts)
+			if err != nil {
+				t.Fatalf("newMethod() failed with %v; want success", err)
+			}
+			if meth.ClientStreaming != c.clientStreaming {
+				t.Errorf("newMethod().ClientStreaming = %v; want %v", meth.ClientStreaming, c.clientStreaming)
+			}
+			if meth.ServerStreaming != c.serverStreaming {
+				t.Errorf("newMethod().ServerStreaming = %v; want %v", meth.ServerStreaming, c.serverStreaming)
+			}
+		})
+	}
+}
+
+func TestNewMethodRejectsPathParamsOnClientStreaming(t *testing.T) {
+	file := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+
+	req := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Req")}}
+	resp := &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Resp")}}
+
+	r := NewRegistry()
+	r.msgs[".example.Req"] = req
+	r.msgs[".example.Resp"] = resp
+
+	svc := &Service{File: file, ServiceDescriptorProto: &descriptorpb.ServiceDescriptorProto{Name: strp("Svc")}}
+	clientStreaming := true
+	md := &descriptorpb.MethodDescriptorProto{
+		Name:            strp("Call"),
+		InputType:       strp(".example.Req"),
+		OutputType:      strp(".example.Resp"),
+		ClientStreaming: &clientStreaming,
+	}
+	opts := &options.HttpRule{Get: "/v1/call/{id}"}
+
+	_, err := r.newMethod(svc, md, opts)
+	if err == nil {
+		t.Fatal("newMethod() succeeded; want an error")
+	}
+	if !strings.Contains(err.Error(), "path parameter in client streaming") {
+		t.Errorf("newMethod() error = %v; want it to mention client streaming", err)
+	}
+}
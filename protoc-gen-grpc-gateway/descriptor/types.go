@@ -0,0 +1,361 @@
+package descriptor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/httprule"
+	descriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// Registry is a registry of information extracted from plugin.CodeGeneratorRequest.
+type Registry struct {
+	// msgs is a mapping from fully-qualified message name to descriptor
+	msgs map[string]*Message
+
+	// enums is a mapping from fully-qualified enum name to descriptor
+	enums map[string]*Enum
+
+	// files is a mapping from .proto filename to descriptor
+	files map[string]*File
+
+	// pkgMap is a user-specified mapping from file path to proto package
+	pkgMap map[string]string
+
+	// pkgAliases is a mapping from package aliases to package paths in go
+	pkgAliases map[string]string
+
+	// rejectUnknownQueryParams determines whether a query parameter that
+	// cannot be bound to any field of the request message should be
+	// treated as an error by the generated handler instead of silently
+	// ignored.
+	rejectUnknownQueryParams bool
+
+	// codecsByContentType is a mapping from HTTP content type to the
+	// Codec used to decode/encode bodies of that type.
+	codecsByContentType map[string]*Codec
+	// defaultCodec is used when content negotiation doesn't otherwise
+	// select a codec.
+	defaultCodec *Codec
+
+	// paramParsers is a mapping from a message's fully-qualified name to
+	// the parser used to bind a path parameter of that message type.
+	paramParsers map[string]ParamParser
+}
+
+// ParamParser is a build-time-registered parser for a message-typed path
+// parameter: a go expression of a function "func(string) (T, error)"
+// plus the imports it needs, the same way Codec pairs a factory
+// expression with the imports it requires.
+type ParamParser struct {
+	// Expr is a go expression of a "func(string) (T, error)" where T is
+	// the go type generated for the registered message.
+	Expr string
+	// Imports is the list of import paths required by Expr.
+	Imports []GoPackage
+}
+
+// NewRegistry returns a new Registry.
+func NewRegistry() *Registry {
+	r := &Registry{
+		msgs:                make(map[string]*Message),
+		enums:               make(map[string]*Enum),
+		files:               make(map[string]*File),
+		codecsByContentType: make(map[string]*Codec),
+		defaultCodec:        CodecJSONPb,
+		paramParsers:        make(map[string]ParamParser),
+	}
+	r.RegisterCodec(CodecJSONPb)
+	r.RegisterCodec(CodecProto)
+	for fqmn, parser := range wellKnownParamParsers {
+		r.paramParsers[fqmn] = parser
+	}
+	return r
+}
+
+// RegisterParamParser registers parser as the parser used to bind a path
+// parameter of the message type named fqmn.
+func (r *Registry) RegisterParamParser(fqmn string, parser ParamParser) {
+	r.paramParsers[fqmn] = parser
+}
+
+// LookupParamParser returns the parser registered for fqmn, if any.
+func (r *Registry) LookupParamParser(fqmn string) (ParamParser, bool) {
+	parser, ok := r.paramParsers[fqmn]
+	return parser, ok
+}
+
+// runtimePackage is the import shared by every well-known parser seeded
+// below; it's the package the generated handler pulls TimestampParam and
+// DurationParam from.
+var runtimePackage = GoPackage{
+	Path: "github.com/gengo/grpc-gateway/runtime",
+	Name: "runtime",
+}
+
+// wellKnownParamParsers seeds every Registry with parsers for the
+// well-known message types users most often want to bind straight from a
+// URL segment, e.g. "/v1/events/{time}" where time is a
+// google.protobuf.Timestamp parsed from RFC3339.
+var wellKnownParamParsers = map[string]ParamParser{
+	".google.protobuf.Timestamp": {Expr: "runtime.TimestampParam", Imports: []GoPackage{runtimePackage}},
+	".google.protobuf.Duration":  {Expr: "runtime.DurationParam", Imports: []GoPackage{runtimePackage}},
+}
+
+// SetRejectUnknownQueryParams sets whether generated handlers should
+// reject requests carrying query parameters that don't bind to any
+// field of the request message. The default is to ignore them.
+func (r *Registry) SetRejectUnknownQueryParams(reject bool) {
+	r.rejectUnknownQueryParams = reject
+}
+
+// RejectUnknownQueryParams returns whether generated handlers should
+// reject unknown query parameters rather than silently ignore them.
+func (r *Registry) RejectUnknownQueryParams() bool {
+	return r.rejectUnknownQueryParams
+}
+
+// LookupMsg looks up a message type by "name".
+// It tries to resolve "name" from "location" if "name" is a relative message name.
+func (r *Registry) LookupMsg(location, name string) (*Message, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("empty message name")
+	}
+	if name[0] == '.' {
+		m, ok := r.msgs[name]
+		if !ok {
+			return nil, fmt.Errorf("no message found: %s", name)
+		}
+		return m, nil
+	}
+
+	components := strings.Split(location, ".")
+	for len(components) > 0 {
+		fqmn := strings.Join(append(components, name), ".")
+		if m, ok := r.msgs[fqmn]; ok {
+			return m, nil
+		}
+		components = components[:len(components)-1]
+	}
+	return nil, fmt.Errorf("no message found: %s", name)
+}
+
+// RegisterMsg registers m under its own fully-qualified name, bypassing
+// the usual file-loading path. It exists for generators and tests that
+// build up *Message values programmatically rather than from a
+// plugin.CodeGeneratorRequest.
+func (r *Registry) RegisterMsg(m *Message) {
+	r.msgs[m.FQMN()] = m
+}
+
+// LookupEnum looks up an enum type by "name", the same way LookupMsg
+// resolves a message type: it tries to resolve "name" from "location"
+// first if "name" is a relative enum name.
+func (r *Registry) LookupEnum(location, name string) (*Enum, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("empty enum name")
+	}
+	if name[0] == '.' {
+		e, ok := r.enums[name]
+		if !ok {
+			return nil, fmt.Errorf("no enum found: %s", name)
+		}
+		return e, nil
+	}
+
+	components := strings.Split(location, ".")
+	for len(components) > 0 {
+		fqen := strings.Join(append(components, name), ".")
+		if e, ok := r.enums[fqen]; ok {
+			return e, nil
+		}
+		components = components[:len(components)-1]
+	}
+	return nil, fmt.Errorf("no enum found: %s", name)
+}
+
+// RegisterEnum registers e under its own fully-qualified name, bypassing
+// the usual file-loading path; see RegisterMsg.
+func (r *Registry) RegisterEnum(e *Enum) {
+	r.enums[e.FQEN()] = e
+}
+
+// LookupFile looks up a file by its proto path, e.g. "a/b/c.proto".
+func (r *Registry) LookupFile(name string) (*File, error) {
+	f, ok := r.files[name]
+	if !ok {
+		return nil, fmt.Errorf("no such file: %s", name)
+	}
+	return f, nil
+}
+
+// File wraps descriptor.FileDescriptorProto for richer features.
+type File struct {
+	*descriptor.FileDescriptorProto
+	// Services is a list of services defined in the file.
+	Services []*Service
+}
+
+// Service wraps descriptor.ServiceDescriptorProto for richer features.
+type Service struct {
+	// File is the file where the service is defined.
+	File *File
+	*descriptor.ServiceDescriptorProto
+	// Methods is a list of methods defined in the service.
+	Methods []*Method
+}
+
+// Method wraps descriptor.MethodDescriptorProto for richer features.
+type Method struct {
+	// Service is the service which this method belongs to.
+	Service *Service
+	*descriptor.MethodDescriptorProto
+
+	// PathTmpl is a compiled representation of the HTTP path template.
+	PathTmpl httprule.Template
+
+	// HTTPMethod is the HTTP method by which this method can be called, e.g. "GET".
+	HTTPMethod string
+
+	// RequestType is the message type of requests to this method.
+	RequestType *Message
+	// ResponseType is the message type of responses from this method.
+	ResponseType *Message
+
+	// ClientStreaming and ServerStreaming mirror
+	// MethodDescriptorProto.GetClientStreaming/GetServerStreaming. They
+	// determine which of the three handler shapes the generator emits:
+	// unary (neither set), server-streaming (ServerStreaming only,
+	// written as chunked JSON lines or an SSE stream), or bidi/client
+	// streaming (either ClientStreaming set, upgraded to a WebSocket).
+	ClientStreaming bool
+	ServerStreaming bool
+
+	// PathParams is the list of parameters provided in the HTTP path.
+	PathParams []Parameter
+
+	// QueryParams is the list of parameters provided via the HTTP query
+	// string. It covers every scalar leaf field of RequestType that is
+	// not already bound by PathParams or Body.
+	QueryParams []QueryParameter
+
+	// Body describes parameters provided in HTTP request body.
+	Body *Body
+}
+
+// Message wraps descriptor.DescriptorProto for richer features.
+type Message struct {
+	// File is the file where the message is defined.
+	File *File
+	// Outers is a list of outer messages if this message is a nested type.
+	Outers []string
+	*descriptor.DescriptorProto
+	// Fields is a list of fields defined in the message.
+	Fields []*Field
+}
+
+// FQMN returns a fully qualified message name of this message.
+func (m *Message) FQMN() string {
+	components := []string{""}
+	if m.File.Package != nil {
+		components = append(components, m.File.GetPackage())
+	}
+	components = append(components, m.Outers...)
+	components = append(components, m.GetName())
+	return strings.Join(components, ".")
+}
+
+// Enum wraps descriptor.EnumDescriptorProto for richer features.
+type Enum struct {
+	// File is the file where the enum is defined.
+	File *File
+	// Outers is a list of outer messages if this enum is nested within one.
+	Outers []string
+	*descriptor.EnumDescriptorProto
+}
+
+// FQEN returns a fully qualified enum name of this enum.
+func (e *Enum) FQEN() string {
+	components := []string{""}
+	if e.File.Package != nil {
+		components = append(components, e.File.GetPackage())
+	}
+	components = append(components, e.Outers...)
+	components = append(components, e.GetName())
+	return strings.Join(components, ".")
+}
+
+// Field wraps descriptor.FieldDescriptorProto for richer features.
+type Field struct {
+	// Message is the message which this field belongs to.
+	Message *Message
+	*descriptor.FieldDescriptorProto
+}
+
+// Parameter is a parameter provided in HTTP request.
+type Parameter struct {
+	// FieldPath is a path to a proto field which this parameter is mapped to.
+	FieldPath
+	// Target is the proto field which this parameter is mapped to.
+	Target *Field
+	// Method is the method which this parameter is used for.
+	Method *Method
+
+	// ParserExpr is a go expression of a "func(string) (T, error)" used
+	// to parse this parameter from its URL segment. It is only set when
+	// Target is a message type bound via a registered param parser;
+	// scalar parameters are parsed by the generator's default
+	// string-to-scalar coercion instead.
+	ParserExpr string
+	// ParserImports is the list of import paths required by ParserExpr.
+	ParserImports []GoPackage
+}
+
+// FieldPath is a path to a field from a request message.
+type FieldPath []FieldPathComponent
+
+// String returns a string representation of the field path.
+func (p FieldPath) String() string {
+	components := make([]string, 0, len(p))
+	for _, c := range p {
+		components = append(components, c.Name)
+	}
+	return strings.Join(components, ".")
+}
+
+// FieldPathComponent is a path component for FieldPath.
+type FieldPathComponent struct {
+	// Name is a name of the proto field which this component corresponds to.
+	Name string
+	// Target is the proto field type of this component.
+	Target *Field
+}
+
+// Body describes a http requtest body to be sent to the method.
+type Body struct {
+	// FieldPath is a path to a field which the request body is mapped to.
+	// nil FieldPath indicates that the whole request body is mapped to the method's request type.
+	FieldPath FieldPath
+
+	// Codec is the build-time default codec used to decode this body.
+	// The generated handler still inspects Content-Type at runtime and
+	// falls back to Codec only when no better match is registered.
+	Codec *Codec
+
+	// DecoderFactoryExpr is a go expression of a factory function to construct a decoder.
+	// It is derived from Codec and kept as a separate field for generator
+	// templates that don't need the rest of the codec metadata.
+	DecoderFactoryExpr string
+	// DecoderImports is the list of import paths required by DecoderFactoryExpr.
+	DecoderImports []GoPackage
+}
+
+// GoPackage represents a golang package
+type GoPackage struct {
+	// Path is the import path of the package.
+	Path string
+	// Name is the package name of the package.
+	Name string
+	// Alias is an alias of the package unique within the current file.
+	Alias string
+}
@@ -0,0 +1,85 @@
+package genswagger
+
+import (
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+)
+
+// messageTypeTag and fieldTag are the field numbers of
+// FileDescriptorProto.message_type and DescriptorProto.field
+// respectively, as declared in descriptor.proto. A SourceCodeInfo
+// location's Path is a sequence of these tags interleaved with the index
+// into the corresponding repeated field, e.g. [4, 2, 2, 0] is the first
+// field of the third top-level message in the file.
+const (
+	messageTypeTag = 4
+	fieldTag       = 2
+)
+
+// protoComment returns the comment proto-docs attach to msg, or to field
+// when it's non-nil, as recorded in msg.File's SourceCodeInfo. It falls
+// back to the empty string when no comment is available.
+//
+// Only top-level messages (msg.Outers empty) are resolved: doing so for
+// nested message/enum types needs each message's index within its
+// parent's nested_type list, which the descriptor package doesn't track
+// yet. That's a narrower follow-up, not a reason to drop comments for
+// the common top-level case.
+func protoComment(msg *descriptor.Message, field *descriptor.Field) string {
+	if len(msg.Outers) > 0 {
+		return ""
+	}
+	info := msg.File.GetSourceCodeInfo()
+	if info == nil {
+		return ""
+	}
+
+	msgIndex := indexOf(len(msg.File.GetMessageType()), func(i int) bool {
+		return msg.File.GetMessageType()[i].GetName() == msg.GetName()
+	})
+	if msgIndex < 0 {
+		return ""
+	}
+	path := []int32{messageTypeTag, int32(msgIndex)}
+
+	if field != nil {
+		fieldIndex := indexOf(len(msg.GetField()), func(i int) bool {
+			return msg.GetField()[i].GetName() == field.GetName()
+		})
+		if fieldIndex < 0 {
+			return ""
+		}
+		path = append(path, fieldTag, int32(fieldIndex))
+	}
+
+	for _, loc := range info.GetLocation() {
+		if !pathEqual(loc.GetPath(), path) {
+			continue
+		}
+		if c := loc.GetLeadingComments(); c != "" {
+			return c
+		}
+		return loc.GetTrailingComments()
+	}
+	return ""
+}
+
+func indexOf(n int, match func(i int) bool) int {
+	for i := 0; i < n; i++ {
+		if match(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+func pathEqual(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package genswagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// Generator renders swagger.json documents from descriptors resolved by
+// a descriptor.Registry.
+type Generator struct {
+	reg *descriptor.Registry
+}
+
+// New returns a new Generator that reads descriptors from reg.
+func New(reg *descriptor.Registry) *Generator {
+	return &Generator{reg: reg}
+}
+
+// Generate renders one swagger.json document per proto file named in
+// targets, as a protoc-gen response file ready to be written to disk.
+func (g *Generator) Generate(targets []string) ([]*plugin.CodeGeneratorResponse_File, error) {
+	var files []*plugin.CodeGeneratorResponse_File
+	for _, target := range targets {
+		file, err := g.reg.LookupFile(target)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := applyTemplate(g.reg, file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", target, err)
+		}
+		content, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSuffix(filepath.Base(target), filepath.Ext(target)) + ".swagger.json"
+		files = append(files, &plugin.CodeGeneratorResponse_File{
+			Name:    proto.String(name),
+			Content: proto.String(string(content)),
+		})
+	}
+	return files, nil
+}
@@ -0,0 +1,130 @@
+package genswagger
+
+import (
+	"strings"
+
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	pbdescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// swaggerTypeAndFormat maps a proto scalar field type to the swagger
+// "type"/"format" pair used to describe it.
+func swaggerTypeAndFormat(t pbdescriptor.FieldDescriptorProto_Type) (string, string) {
+	switch t {
+	case pbdescriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return "number", "double"
+	case pbdescriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return "number", "float"
+	case pbdescriptor.FieldDescriptorProto_TYPE_INT64, pbdescriptor.FieldDescriptorProto_TYPE_SFIXED64, pbdescriptor.FieldDescriptorProto_TYPE_SINT64:
+		return "string", "int64"
+	case pbdescriptor.FieldDescriptorProto_TYPE_UINT64, pbdescriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return "string", "uint64"
+	case pbdescriptor.FieldDescriptorProto_TYPE_INT32, pbdescriptor.FieldDescriptorProto_TYPE_SFIXED32, pbdescriptor.FieldDescriptorProto_TYPE_SINT32:
+		return "integer", "int32"
+	case pbdescriptor.FieldDescriptorProto_TYPE_UINT32, pbdescriptor.FieldDescriptorProto_TYPE_FIXED32:
+		return "integer", "int64"
+	case pbdescriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "boolean", ""
+	case pbdescriptor.FieldDescriptorProto_TYPE_STRING:
+		return "string", ""
+	case pbdescriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "string", "byte"
+	case pbdescriptor.FieldDescriptorProto_TYPE_ENUM:
+		return "string", ""
+	default:
+		return "string", ""
+	}
+}
+
+// swaggerDefinitionName returns the name msg is registered under in the
+// "definitions" map: its fully-qualified proto name with the leading dot
+// stripped. The remaining dots are kept as-is, since swagger names may
+// contain dots.
+func swaggerDefinitionName(msg *descriptor.Message) string {
+	return strings.TrimPrefix(msg.FQMN(), ".")
+}
+
+// schemaForMessage registers msg (and, transitively, every message and
+// enum reachable from its fields) in doc.Definitions and returns a schema
+// object that $refs it.
+func schemaForMessage(doc *swaggerObject, msg *descriptor.Message) (swaggerSchemaObject, error) {
+	name := swaggerDefinitionName(msg)
+	if _, ok := doc.Definitions[name]; !ok {
+		// Reserve the name before recursing so that a cycle through this
+		// message (directly or via another message) terminates instead
+		// of looping forever.
+		doc.Definitions[name] = swaggerSchemaObject{Type: "object"}
+
+		props := make(map[string]swaggerSchemaObject)
+		for _, f := range msg.Fields {
+			fs, err := schemaForField(doc, msg, f)
+			if err != nil {
+				return swaggerSchemaObject{}, err
+			}
+			props[f.GetName()] = fs
+		}
+		doc.Definitions[name] = swaggerSchemaObject{
+			Type:        "object",
+			Description: protoComment(msg, nil),
+			Properties:  props,
+		}
+	}
+	return swaggerSchemaObject{Ref: "#/definitions/" + name}, nil
+}
+
+// swaggerEnumDefinitionName returns the name e is registered under in the
+// "definitions" map, the enum equivalent of swaggerDefinitionName.
+func swaggerEnumDefinitionName(e *descriptor.Enum) string {
+	return strings.TrimPrefix(e.FQEN(), ".")
+}
+
+// schemaForEnum registers e in doc.Definitions as a string schema
+// constrained to its value names, and returns a schema object that $refs
+// it.
+func schemaForEnum(doc *swaggerObject, e *descriptor.Enum) (swaggerSchemaObject, error) {
+	name := swaggerEnumDefinitionName(e)
+	if _, ok := doc.Definitions[name]; !ok {
+		values := make([]string, 0, len(e.GetValue()))
+		for _, v := range e.GetValue() {
+			values = append(values, v.GetName())
+		}
+		doc.Definitions[name] = swaggerSchemaObject{
+			Type: "string",
+			Enum: values,
+		}
+	}
+	return swaggerSchemaObject{Ref: "#/definitions/" + name}, nil
+}
+
+func schemaForField(doc *swaggerObject, msg *descriptor.Message, f *descriptor.Field) (swaggerSchemaObject, error) {
+	var item swaggerSchemaObject
+	switch f.GetType() {
+	case pbdescriptor.FieldDescriptorProto_TYPE_MESSAGE, pbdescriptor.FieldDescriptorProto_TYPE_GROUP:
+		nested, err := doc.registry.LookupMsg(msg.FQMN(), f.GetTypeName())
+		if err != nil {
+			return swaggerSchemaObject{}, err
+		}
+		item, err = schemaForMessage(doc, nested)
+		if err != nil {
+			return swaggerSchemaObject{}, err
+		}
+	case pbdescriptor.FieldDescriptorProto_TYPE_ENUM:
+		enum, err := doc.registry.LookupEnum(msg.FQMN(), f.GetTypeName())
+		if err != nil {
+			return swaggerSchemaObject{}, err
+		}
+		item, err = schemaForEnum(doc, enum)
+		if err != nil {
+			return swaggerSchemaObject{}, err
+		}
+	default:
+		typ, format := swaggerTypeAndFormat(f.GetType())
+		item = swaggerSchemaObject{Type: typ, Format: format}
+	}
+	item.Description = protoComment(msg, f)
+
+	if f.GetLabel() == pbdescriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return swaggerSchemaObject{Type: "array", Items: &item}, nil
+	}
+	return item, nil
+}
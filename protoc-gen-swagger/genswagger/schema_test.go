@@ -0,0 +1,107 @@
+package genswagger
+
+import (
+	"testing"
+
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func strp(s string) *string { return &s }
+
+func newField(msg *descriptor.Message, name string, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptor.Field {
+	fd := &descriptorpb.FieldDescriptorProto{
+		Name:  strp(name),
+		Type:  typ.Enum(),
+		Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if typeName != "" {
+		fd.TypeName = strp(typeName)
+	}
+	return &descriptor.Field{Message: msg, FieldDescriptorProto: fd}
+}
+
+// TestSchemaForMessageSelfReferential ensures a self-referential message
+// (e.g. "message Comment { Comment parent = 1; }") is rendered as a
+// single $ref'd definition instead of recursing forever, mirroring the
+// cycle guard in the descriptor package's query-parameter binding.
+func TestSchemaForMessageSelfReferential(t *testing.T) {
+	file := &descriptor.File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+	comment := &descriptor.Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Comment")}}
+	comment.Fields = []*descriptor.Field{
+		newField(comment, "parent", descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".example.Comment"),
+		newField(comment, "text", descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+	}
+
+	reg := descriptor.NewRegistry()
+	reg.RegisterMsg(comment)
+
+	doc := &swaggerObject{Definitions: make(map[string]swaggerSchemaObject)}
+	doc.registry = reg
+
+	schema, err := schemaForMessage(doc, comment)
+	if err != nil {
+		t.Fatalf("schemaForMessage() failed with %v; want success", err)
+	}
+	if schema.Ref != "#/definitions/example.Comment" {
+		t.Errorf("schemaForMessage() ref = %q; want #/definitions/example.Comment", schema.Ref)
+	}
+	if len(doc.Definitions) != 1 {
+		t.Errorf("len(doc.Definitions) = %d; want 1", len(doc.Definitions))
+	}
+
+	def := doc.Definitions["example.Comment"]
+	parentSchema := def.Properties["parent"]
+	if parentSchema.Ref != "#/definitions/example.Comment" {
+		t.Errorf("parent field schema ref = %q; want #/definitions/example.Comment", parentSchema.Ref)
+	}
+}
+
+// TestSchemaForMessageEnumField ensures an enum-typed field is rendered
+// as a $ref to a string schema listing its allowed values, rather than
+// falling through to an unconstrained "type": "string".
+func TestSchemaForMessageEnumField(t *testing.T) {
+	file := &descriptor.File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+
+	status := &descriptor.Enum{
+		File: file,
+		EnumDescriptorProto: &descriptorpb.EnumDescriptorProto{
+			Name: strp("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: strp("ACTIVE")},
+				{Name: strp("INACTIVE")},
+			},
+		},
+	}
+
+	user := &descriptor.Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("User")}}
+	user.Fields = []*descriptor.Field{
+		newField(user, "status", descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".example.Status"),
+	}
+
+	reg := descriptor.NewRegistry()
+	reg.RegisterMsg(user)
+	reg.RegisterEnum(status)
+
+	doc := &swaggerObject{Definitions: make(map[string]swaggerSchemaObject)}
+	doc.registry = reg
+
+	schema, err := schemaForMessage(doc, user)
+	if err != nil {
+		t.Fatalf("schemaForMessage() failed with %v; want success", err)
+	}
+	if schema.Ref != "#/definitions/example.User" {
+		t.Errorf("schemaForMessage() ref = %q; want #/definitions/example.User", schema.Ref)
+	}
+
+	statusSchema := doc.Definitions["example.User"].Properties["status"]
+	if statusSchema.Ref != "#/definitions/example.Status" {
+		t.Errorf("status field schema ref = %q; want #/definitions/example.Status", statusSchema.Ref)
+	}
+
+	enumDef := doc.Definitions["example.Status"]
+	want := []string{"ACTIVE", "INACTIVE"}
+	if len(enumDef.Enum) != len(want) || enumDef.Enum[0] != want[0] || enumDef.Enum[1] != want[1] {
+		t.Errorf("doc.Definitions[\"example.Status\"].Enum = %v; want %v", enumDef.Enum, want)
+	}
+}
@@ -0,0 +1,210 @@
+package genswagger
+
+import (
+	"fmt"
+
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	pbdescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// applyTemplate renders a single swaggerObject covering every service
+// declared in file.
+func applyTemplate(reg *descriptor.Registry, file *descriptor.File) (*swaggerObject, error) {
+	doc := &swaggerObject{
+		Swagger: "2.0",
+		Info: swaggerInfoObject{
+			Title:   file.GetName(),
+			Version: "version not set",
+		},
+		Paths:       make(map[string]swaggerPathItemObject),
+		Definitions: make(map[string]swaggerSchemaObject),
+		registry:    reg,
+	}
+
+	for _, svc := range file.Services {
+		for _, meth := range svc.Methods {
+			if err := addMethodToSwagger(doc, svc, meth); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return doc, nil
+}
+
+func addMethodToSwagger(doc *swaggerObject, svc *descriptor.Service, meth *descriptor.Method) error {
+	path := meth.PathTmpl.Template
+
+	params, err := swaggerParamsForMethod(doc, meth)
+	if err != nil {
+		return fmt.Errorf("%s.%s: %v", svc.GetName(), meth.GetName(), err)
+	}
+
+	respSchema, err := schemaForMessage(doc, meth.ResponseType)
+	if err != nil {
+		return err
+	}
+
+	op := &swaggerOperationObject{
+		OperationID: fmt.Sprintf("%s_%s", svc.GetName(), meth.GetName()),
+		Parameters:  params,
+		Responses: map[string]swaggerResponseObject{
+			"200": {
+				Description: "A successful response.",
+				Schema:      respSchema,
+			},
+		},
+	}
+
+	item := doc.Paths[path]
+	switch meth.HTTPMethod {
+	case "GET":
+		item.Get = op
+	case "PUT":
+		item.Put = op
+	case "POST":
+		item.Post = op
+	case "DELETE":
+		item.Delete = op
+	case "PATCH":
+		item.Patch = op
+	default:
+		return fmt.Errorf("unsupported HTTP method %q for swagger generation", meth.HTTPMethod)
+	}
+	doc.Paths[path] = item
+	return nil
+}
+
+// swaggerParamsForMethod builds the swagger parameter list for meth: one
+// "path" parameter per meth.PathParams, one "query" parameter per
+// meth.QueryParams, and a "body" parameter derived from meth.Body, in
+// that order.
+func swaggerParamsForMethod(doc *swaggerObject, meth *descriptor.Method) ([]swaggerParameterObject, error) {
+	var params []swaggerParameterObject
+
+	for _, p := range meth.PathParams {
+		param, err := swaggerParamForField(doc, p.FieldPath.String(), "path", true, p.Target)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+
+	for _, p := range meth.QueryParams {
+		param, err := swaggerParamForField(doc, p.FieldPath.String(), "query", false, p.Target)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+
+	if meth.Body != nil {
+		if len(meth.Body.FieldPath) > 0 {
+			last := meth.Body.FieldPath[len(meth.Body.FieldPath)-1]
+			param, err := swaggerBodyParam(doc, meth.Body.FieldPath.String(), last.Target)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, param)
+			return params, nil
+		}
+		schema, err := schemaForMessage(doc, meth.RequestType)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, swaggerParameterObject{
+			Name:     "body",
+			In:       "body",
+			Required: true,
+			Schema:   &schema,
+		})
+	}
+	return params, nil
+}
+
+// swaggerParamForField builds a "path" or "query" swaggerParameterObject
+// for target. Unlike a body field, a path/query parameter is rendered
+// inline rather than via $ref: an enum parameter carries its allowed
+// values directly in Enum, and a repeated parameter is rendered as a
+// swagger array with Items describing the (per above) element type.
+func swaggerParamForField(doc *swaggerObject, name, in string, required bool, target *descriptor.Field) (swaggerParameterObject, error) {
+	typ, format, enum, err := swaggerScalarTypeFormatEnum(doc, target)
+	if err != nil {
+		return swaggerParameterObject{}, err
+	}
+
+	if target.GetLabel() == pbdescriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return swaggerParameterObject{
+			Name:     name,
+			In:       in,
+			Required: required,
+			Type:     "array",
+			Items: &swaggerItemsObject{
+				Type:   typ,
+				Format: format,
+				Enum:   enum,
+			},
+			CollectionFormat: "multi",
+		}, nil
+	}
+	return swaggerParameterObject{
+		Name:     name,
+		In:       in,
+		Required: required,
+		Type:     typ,
+		Format:   format,
+		Enum:     enum,
+	}, nil
+}
+
+// swaggerScalarTypeFormatEnum resolves target's swagger "type"/"format",
+// and, if target is enum-typed, its allowed value names.
+func swaggerScalarTypeFormatEnum(doc *swaggerObject, target *descriptor.Field) (typ, format string, enum []string, err error) {
+	if target.GetType() == pbdescriptor.FieldDescriptorProto_TYPE_ENUM {
+		e, err := doc.registry.LookupEnum(target.Message.FQMN(), target.GetTypeName())
+		if err != nil {
+			return "", "", nil, err
+		}
+		for _, v := range e.GetValue() {
+			enum = append(enum, v.GetName())
+		}
+		return "string", "", enum, nil
+	}
+	typ, format = swaggerTypeAndFormat(target.GetType())
+	return typ, format, nil, nil
+}
+
+// swaggerBodyParam builds the "body" parameter for a request whose body
+// binds to a single named field (the "body: \"user\"" style of
+// google.api.HttpRule), as opposed to the whole request message. When
+// that field is itself message-typed - the common case, e.g.
+// CreateUserRequest{ User user = 1 } - the parameter gets a $ref schema
+// pointing at the field's own (registered) definition rather than a bare
+// scalar type.
+func swaggerBodyParam(doc *swaggerObject, name string, target *descriptor.Field) (swaggerParameterObject, error) {
+	switch target.GetType() {
+	case pbdescriptor.FieldDescriptorProto_TYPE_MESSAGE, pbdescriptor.FieldDescriptorProto_TYPE_GROUP:
+		nested, err := doc.registry.LookupMsg(target.Message.FQMN(), target.GetTypeName())
+		if err != nil {
+			return swaggerParameterObject{}, err
+		}
+		schema, err := schemaForMessage(doc, nested)
+		if err != nil {
+			return swaggerParameterObject{}, err
+		}
+		return swaggerParameterObject{
+			Name:     name,
+			In:       "body",
+			Required: true,
+			Schema:   &schema,
+		}, nil
+	default:
+		typ, format := swaggerTypeAndFormat(target.GetType())
+		return swaggerParameterObject{
+			Name:     name,
+			In:       "body",
+			Required: true,
+			Type:     typ,
+			Format:   format,
+		}, nil
+	}
+}
@@ -0,0 +1,127 @@
+package genswagger
+
+import (
+	"testing"
+
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// TestSwaggerParamsForMethodMessageBody covers the "body: \"user\""
+// style of google.api.HttpRule, where the body binds to a single named
+// field rather than the whole request message. When that field is
+// itself message-typed, the body parameter must carry a $ref schema
+// pointing at the field's own (registered) definition, not a bare
+// scalar type/format.
+func TestSwaggerParamsForMethodMessageBody(t *testing.T) {
+	file := &descriptor.File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+
+	user := &descriptor.Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("User")}}
+	user.Fields = []*descriptor.Field{
+		newField(user, "name", descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+	}
+
+	req := &descriptor.Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("CreateUserRequest")}}
+	userField := newField(req, "user", descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".example.User")
+	req.Fields = []*descriptor.Field{userField}
+
+	reg := descriptor.NewRegistry()
+	reg.RegisterMsg(user)
+	reg.RegisterMsg(req)
+
+	meth := &descriptor.Method{
+		RequestType: req,
+		Body: &descriptor.Body{
+			FieldPath: descriptor.FieldPath{{Name: "user", Target: userField}},
+		},
+	}
+
+	doc := &swaggerObject{Definitions: make(map[string]swaggerSchemaObject)}
+	doc.registry = reg
+
+	params, err := swaggerParamsForMethod(doc, meth)
+	if err != nil {
+		t.Fatalf("swaggerParamsForMethod() failed with %v; want success", err)
+	}
+	if len(params) != 1 {
+		t.Fatalf("len(params) = %d; want 1", len(params))
+	}
+
+	p := params[0]
+	if p.In != "body" || p.Name != "user" {
+		t.Errorf("param = %+v; want body param named \"user\"", p)
+	}
+	if p.Schema == nil || p.Schema.Ref != "#/definitions/example.User" {
+		t.Errorf("param.Schema = %+v; want $ref to #/definitions/example.User", p.Schema)
+	}
+	if p.Type != "" {
+		t.Errorf("param.Type = %q; want empty, a message-typed body param must use Schema instead", p.Type)
+	}
+	if _, ok := doc.Definitions["example.User"]; !ok {
+		t.Errorf("doc.Definitions = %v; want \"example.User\" to be registered", doc.Definitions)
+	}
+}
+
+// TestSwaggerParamsForMethodRepeatedAndEnum covers a repeated scalar
+// query parameter and an enum-typed path parameter, both of which need
+// more than swaggerTypeAndFormat's plain type/format pair to describe
+// correctly.
+func TestSwaggerParamsForMethodRepeatedAndEnum(t *testing.T) {
+	file := &descriptor.File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: strp("example")}}
+
+	status := &descriptor.Enum{
+		File: file,
+		EnumDescriptorProto: &descriptorpb.EnumDescriptorProto{
+			Name: strp("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: strp("ACTIVE")},
+				{Name: strp("INACTIVE")},
+			},
+		},
+	}
+
+	req := &descriptor.Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: strp("Req")}}
+	statusField := newField(req, "status", descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".example.Status")
+	tagsField := newField(req, "tags", descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+	tagsField.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	req.Fields = []*descriptor.Field{statusField, tagsField}
+
+	reg := descriptor.NewRegistry()
+	reg.RegisterMsg(req)
+	reg.RegisterEnum(status)
+
+	meth := &descriptor.Method{
+		RequestType: req,
+		PathParams: []descriptor.Parameter{
+			{FieldPath: descriptor.FieldPath{{Name: "status", Target: statusField}}, Target: statusField},
+		},
+		QueryParams: []descriptor.QueryParameter{
+			{FieldPath: descriptor.FieldPath{{Name: "tags", Target: tagsField}}, Target: tagsField},
+		},
+	}
+
+	doc := &swaggerObject{Definitions: make(map[string]swaggerSchemaObject)}
+	doc.registry = reg
+
+	params, err := swaggerParamsForMethod(doc, meth)
+	if err != nil {
+		t.Fatalf("swaggerParamsForMethod() failed with %v; want success", err)
+	}
+	if len(params) != 2 {
+		t.Fatalf("len(params) = %d; want 2", len(params))
+	}
+
+	statusParam := params[0]
+	want := []string{"ACTIVE", "INACTIVE"}
+	if len(statusParam.Enum) != len(want) || statusParam.Enum[0] != want[0] || statusParam.Enum[1] != want[1] {
+		t.Errorf("status param.Enum = %v; want %v", statusParam.Enum, want)
+	}
+
+	tagsParam := params[1]
+	if tagsParam.Type != "array" || tagsParam.Items == nil || tagsParam.Items.Type != "string" {
+		t.Errorf("tags param = %+v; want type \"array\" with string items", tagsParam)
+	}
+	if tagsParam.CollectionFormat != "multi" {
+		t.Errorf("tags param.CollectionFormat = %q; want \"multi\"", tagsParam.CollectionFormat)
+	}
+}
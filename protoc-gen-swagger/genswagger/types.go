@@ -0,0 +1,86 @@
+// Package genswagger provides a generator that renders the descriptors
+// resolved by the descriptor package as an OpenAPI v2 (Swagger) document.
+package genswagger
+
+import (
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+)
+
+// The types below are a (partial) representation of the OpenAPI v2
+// specification, limited to the fields this generator populates. Field
+// names follow the spec's wire format via the `json` tag rather than Go
+// convention so the struct can be marshaled directly with encoding/json.
+
+type swaggerObject struct {
+	Swagger     string                           `json:"swagger"`
+	Info        swaggerInfoObject                `json:"info"`
+	Paths       map[string]swaggerPathItemObject `json:"paths"`
+	Definitions map[string]swaggerSchemaObject   `json:"definitions,omitempty"`
+
+	// registry resolves message and enum references encountered while
+	// walking fields. It isn't part of the rendered document.
+	registry *descriptor.Registry `json:"-"`
+}
+
+type swaggerInfoObject struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// swaggerPathItemObject holds one swaggerOperationObject per HTTP method
+// that's bound to a given path template.
+type swaggerPathItemObject struct {
+	Get    *swaggerOperationObject `json:"get,omitempty"`
+	Put    *swaggerOperationObject `json:"put,omitempty"`
+	Post   *swaggerOperationObject `json:"post,omitempty"`
+	Delete *swaggerOperationObject `json:"delete,omitempty"`
+	Patch  *swaggerOperationObject `json:"patch,omitempty"`
+}
+
+type swaggerOperationObject struct {
+	Summary     string                           `json:"summary,omitempty"`
+	OperationID string                           `json:"operationId"`
+	Parameters  []swaggerParameterObject         `json:"parameters,omitempty"`
+	Responses   map[string]swaggerResponseObject `json:"responses"`
+}
+
+type swaggerParameterObject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	In          string   `json:"in"` // "path", "query" or "body"
+	Required    bool     `json:"required"`
+	Type        string   `json:"type,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	// Items and CollectionFormat are only set when Type == "array", i.e.
+	// for a repeated path or query parameter.
+	Items            *swaggerItemsObject `json:"items,omitempty"`
+	CollectionFormat string              `json:"collectionFormat,omitempty"`
+	// Schema is only set when In == "body".
+	Schema *swaggerSchemaObject `json:"schema,omitempty"`
+}
+
+// swaggerItemsObject describes the element type of an array-typed
+// parameter; the OpenAPI v2 spec gives it its own (schema-like but
+// distinct) object rather than reusing swaggerSchemaObject.
+type swaggerItemsObject struct {
+	Type   string   `json:"type,omitempty"`
+	Format string   `json:"format,omitempty"`
+	Enum   []string `json:"enum,omitempty"`
+}
+
+type swaggerResponseObject struct {
+	Description string              `json:"description"`
+	Schema      swaggerSchemaObject `json:"schema,omitempty"`
+}
+
+type swaggerSchemaObject struct {
+	Ref         string                         `json:"$ref,omitempty"`
+	Type        string                         `json:"type,omitempty"`
+	Format      string                         `json:"format,omitempty"`
+	Description string                         `json:"description,omitempty"`
+	Properties  map[string]swaggerSchemaObject `json:"properties,omitempty"`
+	Items       *swaggerSchemaObject           `json:"items,omitempty"`
+	// Enum lists the allowed values of an enum-typed schema.
+	Enum []string `json:"enum,omitempty"`
+}
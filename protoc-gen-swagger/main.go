@@ -0,0 +1,77 @@
+// Command protoc-gen-swagger is a protoc plugin that generates a
+// swagger.json file per proto file from the same descriptors that
+// protoc-gen-grpc-gateway uses to generate gateway code.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/gengo/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	"github.com/gengo/grpc-gateway/protoc-gen-swagger/genswagger"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+var importPrefix = flag.String("import_prefix", "", "prefix to be added to go package paths for imported proto files")
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	req, err := readRequest(os.Stdin)
+	if err != nil {
+		glog.Fatal(err)
+	}
+
+	reg := descriptor.NewRegistry()
+	if err := reg.Load(req); err != nil {
+		emitError(err)
+		return
+	}
+
+	var targets []string
+	for _, target := range req.FileToGenerate {
+		targets = append(targets, target)
+	}
+
+	g := genswagger.New(reg)
+	out, err := g.Generate(targets)
+	if err != nil {
+		emitError(err)
+		return
+	}
+	emitFiles(out)
+}
+
+func readRequest(r *os.File) (*plugin.CodeGeneratorRequest, error) {
+	input, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	req := new(plugin.CodeGeneratorRequest)
+	if err := proto.Unmarshal(input, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func emitFiles(out []*plugin.CodeGeneratorResponse_File) {
+	emitResp(&plugin.CodeGeneratorResponse{File: out})
+}
+
+func emitError(err error) {
+	emitResp(&plugin.CodeGeneratorResponse{Error: proto.String(err.Error())})
+}
+
+func emitResp(resp *plugin.CodeGeneratorResponse) {
+	buf, err := proto.Marshal(resp)
+	if err != nil {
+		glog.Fatal(err)
+	}
+	if _, err := os.Stdout.Write(buf); err != nil {
+		glog.Fatal(err)
+	}
+}